@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAccessLogFormat is the Apache Common Log Format, plus %D
+// (elapsed microseconds), used when Options.AccessLogFormat is empty.
+const DefaultAccessLogFormat = `%h %l %u %t "%r" %>s %b %D`
+
+// jsonAccessLogFormat is the sentinel AccessLogFormat value that
+// switches the middleware to structured JSON lines instead of the
+// templated text format.
+const jsonAccessLogFormat = "json"
+
+// responseRecorder wraps an http.ResponseWriter so the access log
+// middleware can observe the status code and byte count a handler
+// actually wrote.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// accessLogMiddleware wraps next with an Apache/CLF-style (or
+// structured JSON) access log written to out, formatted per format.
+func accessLogMiddleware(next http.Handler, out io.Writer, format string) http.Handler {
+	if format == "" {
+		format = DefaultAccessLogFormat
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w}
+		start := time.Now()
+
+		next.ServeHTTP(rec, r)
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		elapsed := time.Since(start)
+
+		if format == jsonAccessLogFormat {
+			writeJSONAccessLog(out, r, status, rec.bytes, elapsed)
+			return
+		}
+		fmt.Fprintln(out, renderAccessLog(format, r, status, rec.bytes, elapsed))
+	})
+}
+
+func writeJSONAccessLog(out io.Writer, r *http.Request, status, bytes int, elapsed time.Duration) {
+	entry := map[string]interface{}{
+		"remoteHost": remoteHost(r),
+		"time":       time.Now().Format(time.RFC3339),
+		"method":     r.Method,
+		"path":       r.URL.RequestURI(),
+		"status":     status,
+		"bytes":      bytes,
+		"durationUs": elapsed.Microseconds(),
+	}
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(out, string(js))
+}
+
+// renderAccessLog expands a small template language over format:
+// %h remote host, %l remote logname (always "-"), %u remote user
+// (always "-"), %t timestamp, %r request line, %>s status,
+// %b response size in bytes, %D elapsed time in microseconds.
+func renderAccessLog(format string, r *http.Request, status, bytes int, elapsed time.Duration) string {
+	replacer := strings.NewReplacer(
+		"%h", remoteHost(r),
+		"%l", "-",
+		"%u", "-",
+		"%t", "["+time.Now().Format("02/Jan/2006:15:04:05 -0700")+"]",
+		"%r", fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), r.Proto),
+		"%>s", fmt.Sprintf("%d", status),
+		"%b", fmt.Sprintf("%d", bytes),
+		"%D", fmt.Sprintf("%d", elapsed.Microseconds()),
+	)
+	return replacer.Replace(format)
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
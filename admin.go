@@ -0,0 +1,58 @@
+package main
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/ItserX/MySQL_Manager/migrations"
+)
+
+// AdminMigrate triggers migrations.Migrate("up") against db and
+// returns the ids it applied as JSON.
+//
+// Requests must present adminToken as a bearer token in the
+// Authorization header; a missing, empty, or mismatched token gets
+// http.StatusUnauthorized. An empty adminToken refuses every request,
+// so the endpoint is closed by default rather than unprotected.
+//
+// A migration that adds or drops a table is not picked up by the
+// already-running explorer: table introspection happens once, in
+// NewDBExplorerWithOptions, so such a migration needs a process
+// restart before the new table is reachable through the other routes.
+func AdminMigrate(db *sql.DB, dialect Dialect, adminToken string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !validAdminToken(r, adminToken) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		applied, err := migrations.Migrate(db, dialect, "up")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{
+			"response": map[string]interface{}{
+				"applied": applied,
+			},
+		})
+	}
+}
+
+// validAdminToken reports whether r carries adminToken as a bearer
+// token in its Authorization header.
+func validAdminToken(r *http.Request, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	got := strings.TrimPrefix(header, prefix)
+	return subtle.ConstantTimeCompare([]byte(got), []byte(adminToken)) == 1
+}
@@ -0,0 +1,242 @@
+// Package builder assembles parameterized SQL for the explorer's CRUD
+// handlers, in the spirit of xorm.io/builder: each statement type
+// exposes a small fluent API and Build produces a (sql, args) pair
+// using the caller's Dialect for identifier quoting and placeholders.
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dialect is the subset of the explorer's Dialect interface the
+// builder needs to render identifiers and placeholders. Any type with
+// these two methods satisfies it, so callers can pass their own
+// Dialect value directly.
+type Dialect interface {
+	Quote(ident string) string
+	Placeholder(i int) string
+}
+
+// Cond renders itself as a SQL boolean expression. argOffset is the
+// 1-indexed placeholder number the first argument in this Cond should
+// receive; ToSQL returns the rendered expression and the args that
+// fill its placeholders, in order.
+type Cond interface {
+	ToSQL(d Dialect, argOffset int) (string, []interface{})
+}
+
+// Eq is an equality condition: column = value.
+type Eq map[string]interface{}
+
+func (e Eq) ToSQL(d Dialect, argOffset int) (string, []interface{}) {
+	if len(e) == 0 {
+		return "", nil
+	}
+
+	cols := make([]string, 0, len(e))
+	for col := range e {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	parts := make([]string, 0, len(cols))
+	args := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		parts = append(parts, d.Quote(col)+" = "+d.Placeholder(argOffset+len(args)))
+		args = append(args, e[col])
+	}
+	return strings.Join(parts, " AND "), args
+}
+
+// SelectBuilder builds a SELECT statement.
+type SelectBuilder struct {
+	cols   []string
+	table  string
+	cond   Cond
+	limit  int
+	offset int
+}
+
+// Select starts a SELECT statement over cols; no cols means "*".
+func Select(cols ...string) *SelectBuilder {
+	return &SelectBuilder{cols: cols, limit: -1, offset: -1}
+}
+
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.table = table
+	return b
+}
+
+func (b *SelectBuilder) Where(cond Cond) *SelectBuilder {
+	b.cond = cond
+	return b
+}
+
+func (b *SelectBuilder) Limit(n int) *SelectBuilder {
+	b.limit = n
+	return b
+}
+
+func (b *SelectBuilder) Offset(n int) *SelectBuilder {
+	b.offset = n
+	return b
+}
+
+func (b *SelectBuilder) Build(d Dialect) (string, []interface{}) {
+	cols := "*"
+	if len(b.cols) > 0 {
+		quoted := make([]string, len(b.cols))
+		for i, c := range b.cols {
+			quoted[i] = d.Quote(c)
+		}
+		cols = strings.Join(quoted, ", ")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", cols, d.Quote(b.table))
+	args := make([]interface{}, 0)
+
+	if b.cond != nil {
+		whereSQL, whereArgs := b.cond.ToSQL(d, 1)
+		if whereSQL != "" {
+			query += " WHERE " + whereSQL
+			args = append(args, whereArgs...)
+		}
+	}
+	if b.limit >= 0 {
+		query += fmt.Sprintf(" LIMIT %d", b.limit)
+	}
+	if b.offset >= 0 {
+		query += fmt.Sprintf(" OFFSET %d", b.offset)
+	}
+
+	return query, args
+}
+
+// InsertBuilder builds an INSERT statement.
+type InsertBuilder struct {
+	table     string
+	cols      []string
+	values    []interface{}
+	returning string
+}
+
+// Insert starts an INSERT statement into table.
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+func (b *InsertBuilder) Cols(cols ...string) *InsertBuilder {
+	b.cols = cols
+	return b
+}
+
+func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	b.values = values
+	return b
+}
+
+// Returning appends a RETURNING clause for col, for dialects (e.g.
+// PostgreSQL) that recover the inserted id that way instead of
+// through sql.Result.LastInsertId. Callers on those dialects should
+// run the built query with QueryRow and scan the returned column.
+func (b *InsertBuilder) Returning(col string) *InsertBuilder {
+	b.returning = col
+	return b
+}
+
+func (b *InsertBuilder) Build(d Dialect) (string, []interface{}) {
+	quoted := make([]string, len(b.cols))
+	placeholders := make([]string, len(b.cols))
+	for i, c := range b.cols {
+		quoted[i] = d.Quote(c)
+		placeholders[i] = d.Placeholder(i + 1)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.Quote(b.table), strings.Join(quoted, ", "), strings.Join(placeholders, ", "))
+	if b.returning != "" {
+		query += " RETURNING " + d.Quote(b.returning)
+	}
+	return query, b.values
+}
+
+// UpdateBuilder builds an UPDATE statement.
+type UpdateBuilder struct {
+	table string
+	set   map[string]interface{}
+	cond  Cond
+}
+
+// Update starts an UPDATE statement against table.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+func (b *UpdateBuilder) Set(values map[string]interface{}) *UpdateBuilder {
+	b.set = values
+	return b
+}
+
+func (b *UpdateBuilder) Where(cond Cond) *UpdateBuilder {
+	b.cond = cond
+	return b
+}
+
+func (b *UpdateBuilder) Build(d Dialect) (string, []interface{}) {
+	cols := make([]string, 0, len(b.set))
+	for col := range b.set {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	setParts := make([]string, 0, len(cols))
+	args := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		setParts = append(setParts, d.Quote(col)+" = "+d.Placeholder(len(args)+1))
+		args = append(args, b.set[col])
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s", d.Quote(b.table), strings.Join(setParts, ", "))
+
+	if b.cond != nil {
+		whereSQL, whereArgs := b.cond.ToSQL(d, len(args)+1)
+		if whereSQL != "" {
+			query += " WHERE " + whereSQL
+			args = append(args, whereArgs...)
+		}
+	}
+
+	return query, args
+}
+
+// DeleteBuilder builds a DELETE statement.
+type DeleteBuilder struct {
+	table string
+	cond  Cond
+}
+
+// Delete starts a DELETE statement against table.
+func Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{table: table}
+}
+
+func (b *DeleteBuilder) Where(cond Cond) *DeleteBuilder {
+	b.cond = cond
+	return b
+}
+
+func (b *DeleteBuilder) Build(d Dialect) (string, []interface{}) {
+	query := fmt.Sprintf("DELETE FROM %s", d.Quote(b.table))
+	args := make([]interface{}, 0)
+
+	if b.cond != nil {
+		whereSQL, whereArgs := b.cond.ToSQL(d, 1)
+		if whereSQL != "" {
+			query += " WHERE " + whereSQL
+			args = append(args, whereArgs...)
+		}
+	}
+
+	return query, args
+}
@@ -0,0 +1,136 @@
+package builder
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// mysqlLikeDialect mirrors the explorer's mysqlDialect well enough to
+// exercise Build: backtick-quoted identifiers, "?" placeholders.
+type mysqlLikeDialect struct{}
+
+func (mysqlLikeDialect) Quote(ident string) string { return "`" + ident + "`" }
+func (mysqlLikeDialect) Placeholder(i int) string  { return "?" }
+
+// postgresLikeDialect mirrors the explorer's postgresDialect: double-quoted
+// identifiers, positional "$N" placeholders.
+type postgresLikeDialect struct{}
+
+func (postgresLikeDialect) Quote(ident string) string { return `"` + ident + `"` }
+func (postgresLikeDialect) Placeholder(i int) string  { return "$" + strconv.Itoa(i) }
+
+func TestSelectBuilderBuild(t *testing.T) {
+	query, args := Select().From("users").Where(Eq{"id": 5}).Build(mysqlLikeDialect{})
+
+	wantQuery := "SELECT * FROM `users` WHERE `id` = ?"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{5}) {
+		t.Errorf("args = %v, want [5]", args)
+	}
+}
+
+func TestSelectBuilderColsLimitOffset(t *testing.T) {
+	query, args := Select("id", "name").From("users").Limit(10).Offset(20).Build(mysqlLikeDialect{})
+
+	wantQuery := "SELECT `id`, `name` FROM `users` LIMIT 10 OFFSET 20"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %v, want none", args)
+	}
+}
+
+func TestSelectBuilderMultiColumnEqIsSortedAndNumberedInOrder(t *testing.T) {
+	query, args := Select().From("users").Where(Eq{"b": 2, "a": 1}).Build(postgresLikeDialect{})
+
+	wantQuery := `SELECT * FROM "users" WHERE "a" = $1 AND "b" = $2`
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2}) {
+		t.Errorf("args = %v, want [1 2] matching the sorted column order", args)
+	}
+}
+
+func TestInsertBuilderBuild(t *testing.T) {
+	query, args := Insert("users").Cols("name", "age").Values("alice", 30).Build(mysqlLikeDialect{})
+
+	wantQuery := "INSERT INTO `users` (`name`, `age`) VALUES (?, ?)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"alice", 30}) {
+		t.Errorf("args = %v, want [alice 30]", args)
+	}
+}
+
+func TestInsertBuilderReturning(t *testing.T) {
+	query, args := Insert("users").Cols("name").Values("alice").Returning("id").Build(postgresLikeDialect{})
+
+	wantQuery := `INSERT INTO "users" ("name") VALUES ($1) RETURNING "id"`
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"alice"}) {
+		t.Errorf("args = %v, want [alice]", args)
+	}
+}
+
+func TestUpdateBuilderSetIsSortedAndWhereContinuesNumbering(t *testing.T) {
+	query, args := Update("users").Set(map[string]interface{}{"name": "bob", "age": 31}).Where(Eq{"id": 5}).Build(postgresLikeDialect{})
+
+	wantQuery := `UPDATE "users" SET "age" = $1, "name" = $2 WHERE "id" = $3`
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{31, "bob", 5}) {
+		t.Errorf("args = %v, want [31 bob 5]", args)
+	}
+}
+
+func TestUpdateBuilderNoWhere(t *testing.T) {
+	query, args := Update("users").Set(map[string]interface{}{"name": "bob"}).Build(mysqlLikeDialect{})
+
+	wantQuery := "UPDATE `users` SET `name` = ?"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"bob"}) {
+		t.Errorf("args = %v, want [bob]", args)
+	}
+}
+
+func TestDeleteBuilderBuild(t *testing.T) {
+	query, args := Delete("users").Where(Eq{"id": 5}).Build(mysqlLikeDialect{})
+
+	wantQuery := "DELETE FROM `users` WHERE `id` = ?"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{5}) {
+		t.Errorf("args = %v, want [5]", args)
+	}
+}
+
+func TestDeleteBuilderNoWhere(t *testing.T) {
+	query, args := Delete("users").Build(mysqlLikeDialect{})
+
+	wantQuery := "DELETE FROM `users`"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %v, want none", args)
+	}
+}
+
+func TestEqEmptyRendersNothing(t *testing.T) {
+	sql, args := Eq{}.ToSQL(mysqlLikeDialect{}, 1)
+	if sql != "" || args != nil {
+		t.Errorf("ToSQL() = (%q, %v), want (\"\", nil) for an empty Eq", sql, args)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cacher fronts the read handlers (GetTables, GetTableValues, GetRecord)
+// so repeated list/get calls don't round-trip to the database. Entries
+// are invalidated per-table whenever a write handler touches that
+// table.
+type Cacher interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, val []byte, ttl time.Duration)
+	// Invalidate drops every entry whose key was recorded against
+	// table, typically called after an Insert/Update/Delete.
+	Invalidate(table string)
+}
+
+// noopCacher is the default when no Cacher is configured: every Get
+// misses, every Put/Invalidate is a no-op.
+type noopCacher struct{}
+
+func (noopCacher) Get(key string) ([]byte, bool)                 { return nil, false }
+func (noopCacher) Put(key string, val []byte, ttl time.Duration) {}
+func (noopCacher) Invalidate(table string)                       {}
+
+type memoryEntry struct {
+	key       string
+	val       []byte
+	table     string
+	expiresAt time.Time
+}
+
+// MemoryStore is a plain in-process map keyed by cache key, with no
+// eviction of its own; NewLRUCacher2 layers capacity/TTL eviction on
+// top of it.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *MemoryStore) get(key string) (*memoryEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	return e, ok
+}
+
+func (s *MemoryStore) set(e *memoryEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[e.key] = e
+}
+
+func (s *MemoryStore) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// lruCacher is an LRU cache over a MemoryStore: it caps the number of
+// live entries and expires them after ttl, evicting the least recently
+// used entry once capacity is exceeded.
+type lruCacher struct {
+	mu       sync.Mutex
+	store    *MemoryStore
+	ttl      time.Duration
+	capacity int
+	order    *list.List
+	elems    map[string]*list.Element
+}
+
+// NewLRUCacher2 wraps store with LRU eviction, expiring entries after
+// ttl and keeping at most capacity of them.
+func NewLRUCacher2(store *MemoryStore, ttl time.Duration, capacity int) Cacher {
+	return &lruCacher{
+		store:    store,
+		ttl:      ttl,
+		capacity: capacity,
+		order:    list.New(),
+		elems:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCacher) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.store.get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		c.removeLocked(key)
+		return nil, false
+	}
+
+	if elem, ok := c.elems[key]; ok {
+		c.order.MoveToFront(elem)
+	}
+	return e.val, true
+}
+
+func (c *lruCacher) Put(key string, val []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	c.store.set(&memoryEntry{
+		key:       key,
+		val:       val,
+		table:     tableFromCacheKey(key),
+		expiresAt: time.Now().Add(ttl),
+	})
+
+	if elem, ok := c.elems[key]; ok {
+		c.order.MoveToFront(elem)
+	} else {
+		c.elems[key] = c.order.PushFront(key)
+	}
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		back := c.order.Back()
+		if back == nil {
+			break
+		}
+		c.removeLocked(back.Value.(string))
+	}
+}
+
+func (c *lruCacher) Invalidate(table string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.elems {
+		e, ok := c.store.get(key)
+		if ok && e.table == table {
+			c.order.Remove(elem)
+			delete(c.elems, key)
+			c.store.delete(key)
+		}
+	}
+}
+
+// removeLocked drops key from both the store and the LRU bookkeeping;
+// callers must already hold c.mu.
+func (c *lruCacher) removeLocked(key string) {
+	if elem, ok := c.elems[key]; ok {
+		c.order.Remove(elem)
+		delete(c.elems, key)
+	}
+	c.store.delete(key)
+}
+
+// cacheKey builds the method+path+query key read handlers cache under.
+func cacheKey(method, path, rawQuery string) string {
+	if rawQuery == "" {
+		return method + " " + path
+	}
+	return method + " " + path + "?" + rawQuery
+}
+
+// tableFromCacheKey recovers the table name a cache key was built for,
+// so Invalidate can target it without a separate index. Keys always
+// look like "GET /table..." once the leading slash is stripped.
+func tableFromCacheKey(key string) string {
+	parts := strings.SplitN(key, " ", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	path := strings.TrimPrefix(parts[1], "/")
+	path = strings.SplitN(path, "?", 2)[0]
+	segments := strings.SplitN(path, "/", 2)
+	return segments[0]
+}
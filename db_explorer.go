@@ -13,6 +13,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/ItserX/MySQL_Manager/builder"
 )
 
 type Table struct {
@@ -28,34 +31,65 @@ func CloseCheck(rows *sql.Rows) {
 	}
 }
 
+// Options configures the optional, database-agnostic behavior of
+// NewDBExplorerWithOptions. The zero value matches NewDBExplorer.
+type Options struct {
+	// Cacher fronts the read handlers. A nil Cacher disables caching.
+	Cacher Cacher
+	// CacheTTL is how long a cached response stays fresh. Defaults to
+	// 30 seconds when a Cacher is set but CacheTTL is zero.
+	CacheTTL time.Duration
+	// AccessLog, when set, receives one access log line per request.
+	AccessLog io.Writer
+	// AccessLogFormat selects the line format written to AccessLog.
+	// Defaults to DefaultAccessLogFormat; the literal value "json"
+	// switches to structured JSON lines instead.
+	AccessLogFormat string
+	// AdminToken protects POST /_admin/migrate: requests must present
+	// it as a bearer token. An empty AdminToken disables the endpoint
+	// entirely rather than running it unprotected.
+	AdminToken string
+}
+
+// NewDBExplorer builds a DB Explorer with no caching, matching this
+// package's original behavior.
 func NewDBExplorer(db *sql.DB) (http.Handler, error) {
+	return NewDBExplorerWithOptions(db, Options{})
+}
+
+// NewDBExplorerWithOptions builds a DB Explorer the same way
+// NewDBExplorer does, additionally wiring in whatever Cacher opts
+// provides (a no-op cacher when opts.Cacher is nil).
+//
+// Tables are introspected once, at construction time. A migration
+// applied later through POST /_admin/migrate that adds or drops a
+// table will not be reflected here until the process is restarted.
+func NewDBExplorerWithOptions(db *sql.DB, opts Options) (http.Handler, error) {
+	cacher := opts.Cacher
+	if cacher == nil {
+		cacher = noopCacher{}
+	}
+	cacheTTL := opts.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = 30 * time.Second
+	}
+
+	dialect := dialectForDriver(db)
 	tables := make(map[string]*Table)
 
-	rows, err := db.Query("SHOW TABLES")
+	tableNames, err := dialect.ListTables(db)
 	if err != nil {
 		log.Print(err.Error())
 		return nil, err
 	}
 
-	for rows.Next() {
-		var tableName string
-
-		err = rows.Scan(&tableName)
-		if err != nil {
-			log.Print(err.Error())
-			return nil, err
-		}
-
+	for _, tableName := range tableNames {
 		tables[tableName] = &Table{name: tableName}
 	}
 
-	err = rows.Close()
-	if err != nil {
-		return nil, err
-	}
-
+	var rows *sql.Rows
 	for _, table := range tables {
-		rows, err = db.Query("SELECT * FROM " + table.name)
+		rows, err = db.Query("SELECT * FROM " + dialect.Quote(table.name))
 		if err != nil {
 			log.Print(err.Error())
 			return nil, err
@@ -83,12 +117,15 @@ func NewDBExplorer(db *sql.DB) (http.Handler, error) {
 	}
 
 	reqs := []Req{
-		{path: `^/{1}$`, method: "GET", function: GetTables(tables)},
-		{path: `^\/[^\/?]+(:?(limit=\d+(&offset=\d+)?|offset=d+(&limit=\d+)?)?)?$`, method: "GET", function: GetTableValues(tables, db)},
-		{path: `^/[a-zA-Z][a-zA-Z0-9]*/\d+$`, method: "GET", function: GetRecord(tables, db)},
-		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/$`, method: "PUT", function: PutRecord(tables, db)},
-		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/\d+$`, method: "POST", function: UpdateRecord(tables, db)},
-		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/\d+$`, method: "DELETE", function: DeleteRecord(tables, db)},
+		{path: `^/{1}$`, method: "GET", function: GetTables(tables, cacher, cacheTTL)},
+		{path: `^/openapi\.json$`, method: "GET", function: GetOpenAPI(tables)},
+		{path: `^/_admin/migrate$`, method: "POST", function: AdminMigrate(db, dialect, opts.AdminToken)},
+		{path: `^/_schema(/[a-zA-Z][a-zA-Z0-9]*)?$`, method: "GET", function: GetSchema(tables)},
+		{path: `^\/[^\/?]+(:?(limit=\d+(&offset=\d+)?|offset=d+(&limit=\d+)?)?)?$`, method: "GET", function: GetTableValues(tables, db, dialect, cacher, cacheTTL)},
+		{path: `^/[a-zA-Z][a-zA-Z0-9]*/\d+$`, method: "GET", function: GetRecord(tables, db, dialect, cacher, cacheTTL)},
+		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/$`, method: "PUT", function: PutRecord(tables, db, dialect, cacher)},
+		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/\d+$`, method: "POST", function: UpdateRecord(tables, db, dialect, cacher)},
+		{path: `^/[a-zA-Z][a-zA-Z/0-9]*/\d+$`, method: "DELETE", function: DeleteRecord(tables, db, dialect, cacher)},
 	}
 
 	mux := http.NewServeMux()
@@ -101,6 +138,10 @@ func NewDBExplorer(db *sql.DB) (http.Handler, error) {
 		}
 	})
 
+	if opts.AccessLog != nil {
+		return accessLogMiddleware(mux, opts.AccessLog, opts.AccessLogFormat), nil
+	}
+
 	return mux, nil
 }
 
@@ -198,8 +239,17 @@ func CastingValues(table *Table, values []interface{}) ([]interface{}, error) {
 	return values, nil
 }
 
-func GetTables(tables map[string]*Table) func(w http.ResponseWriter, r *http.Request) {
+func GetTables(tables map[string]*Table, cacher Cacher, ttl time.Duration) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		key := cacheKey(r.Method, r.URL.Path, r.URL.RawQuery)
+		if cached, ok := cacher.Get(key); ok {
+			_, err := w.Write(cached)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
 		tableNames := make([]string, 0)
 		for _, val := range tables {
 			tableNames = append(tableNames, val.name)
@@ -217,6 +267,7 @@ func GetTables(tables map[string]*Table) func(w http.ResponseWriter, r *http.Req
 			return
 		}
 		fmt.Println(js)
+		cacher.Put(key, js, ttl)
 		_, err = w.Write(js)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -226,9 +277,18 @@ func GetTables(tables map[string]*Table) func(w http.ResponseWriter, r *http.Req
 	}
 }
 
-func GetTableValues(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter, r *http.Request) {
+func GetTableValues(tables map[string]*Table, db *sql.DB, dialect Dialect, cacher Cacher, ttl time.Duration) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 
+		key := cacheKey(r.Method, r.URL.Path, r.URL.RawQuery)
+		if cached, ok := cacher.Get(key); ok {
+			_, err := w.Write(cached)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
 		table, err := CheckTable(w, r, tables)
 		if err != nil {
 			log.Print(err.Error())
@@ -259,7 +319,11 @@ func GetTableValues(tables map[string]*Table, db *sql.DB) func(w http.ResponseWr
 			offQuery = "0"
 		}
 
-		rows, err := db.Query("SELECT * FROM " + table.name + " LIMIT " + limQuery + " OFFSET " + offQuery)
+		lim, _ := strconv.Atoi(limQuery)
+		off, _ := strconv.Atoi(offQuery)
+
+		sqlQuery, sqlArgs := builder.Select().From(table.name).Limit(lim).Offset(off).Build(dialect)
+		rows, err := db.Query(sqlQuery, sqlArgs...)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -310,6 +374,7 @@ func GetTableValues(tables map[string]*Table, db *sql.DB) func(w http.ResponseWr
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		cacher.Put(key, js, ttl)
 		_, err = w.Write(js)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -319,8 +384,17 @@ func GetTableValues(tables map[string]*Table, db *sql.DB) func(w http.ResponseWr
 	}
 }
 
-func GetRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter, r *http.Request) {
+func GetRecord(tables map[string]*Table, db *sql.DB, dialect Dialect, cacher Cacher, ttl time.Duration) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		key := cacheKey(r.Method, r.URL.Path, r.URL.RawQuery)
+		if cached, ok := cacher.Get(key); ok {
+			_, err := w.Write(cached)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
 		table, err := CheckTable(w, r, tables)
 		if err != nil {
 			log.Print(err.Error())
@@ -348,9 +422,10 @@ func GetRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter,
 			return
 		}
 
+		existsSQL, existsArgs := builder.Select().From(table.name).Where(builder.Eq{idName: id}).Build(dialect)
 		var exists bool
-		checkQuery := "SELECT EXISTS(SELECT * FROM " + table.name + " WHERE " + idName + " = ?" + ")"
-		err = db.QueryRow(checkQuery, id).Scan(&exists)
+		checkQuery := "SELECT EXISTS(" + existsSQL + ")"
+		err = db.QueryRow(checkQuery, existsArgs...).Scan(&exists)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -373,8 +448,8 @@ func GetRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter,
 		}
 
 		values := make([]interface{}, len(table.columns))
-		query := "SELECT * FROM " + table.name + " WHERE " + idName + " = " + id
-		row, err := db.Query(query)
+		query, queryArgs := builder.Select().From(table.name).Where(builder.Eq{idName: id}).Build(dialect)
+		row, err := db.Query(query, queryArgs...)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -416,6 +491,7 @@ func GetRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter,
 			http.Error(w, "json marshal error", http.StatusInternalServerError)
 			return
 		}
+		cacher.Put(key, js, ttl)
 		_, err = w.Write(js)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -434,7 +510,7 @@ func CheckColumn(val string, table *Table) bool {
 	return false
 }
 
-func PutRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter, r *http.Request) {
+func PutRecord(tables map[string]*Table, db *sql.DB, dialect Dialect, cacher Cacher) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		table, err := CheckTable(w, r, tables)
 		if err != nil {
@@ -468,7 +544,6 @@ func PutRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter,
 		}
 
 		respFields := make([]string, 0)
-		placeholders := make([]string, 0)
 		respValues := make([]interface{}, 0)
 		idName := ""
 		for key, val := range values {
@@ -476,37 +551,45 @@ func PutRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter,
 			case !strings.Contains(key, "id") && CheckType(key, val, table):
 				respFields = append(respFields, key)
 				respValues = append(respValues, val)
-				placeholders = append(placeholders, "?")
 			case !strings.Contains(key, "id"):
 				respFields = append(respFields, key)
 				respValues = append(respValues, "")
-				placeholders = append(placeholders, "?")
 			default:
 				idName = key
 			}
 		}
 
-		fields := strings.Join(respFields, ", ")
+		insert := builder.Insert(table.name).Cols(respFields...).Values(respValues...)
 
-		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.name, fields, strings.Join(placeholders, ", "))
-		stmt, err := db.Prepare(query)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		defer stmt.Close()
+		var resID int64
+		if dialect.SupportsReturning() {
+			query, queryArgs := insert.Returning(idName).Build(dialect)
+			if err := db.QueryRow(query, queryArgs...).Scan(&resID); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			query, queryArgs := insert.Build(dialect)
+			stmt, err := db.Prepare(query)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer stmt.Close()
 
-		res, err := stmt.Exec(respValues...)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+			res, err := stmt.Exec(queryArgs...)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 
-		resID, err := res.LastInsertId()
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			resID, err = dialect.LastInsertID(res, idName)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 		}
+		cacher.Invalidate(table.name)
 		resp := map[string]interface{}{
 			"response": map[string]interface{}{
 				idName: resID,
@@ -548,7 +631,7 @@ func CheckType(key string, currentVal interface{}, table *Table) bool {
 	}
 	return true
 }
-func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter, r *http.Request) {
+func UpdateRecord(tables map[string]*Table, db *sql.DB, dialect Dialect, cacher Cacher) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		table, err := CheckTable(w, r, tables)
 		if err != nil {
@@ -569,9 +652,10 @@ func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 				idName = table.columns[i]
 			}
 		}
+		existsSQL, existsArgs := builder.Select().From(table.name).Where(builder.Eq{idName: id}).Build(dialect)
 		var exists bool
-		query := fmt.Sprintf("SELECT EXISTS (SELECT * FROM %s WHERE %s=?)", table.name, idName)
-		err = db.QueryRow(query, id).Scan(&exists)
+		query := "SELECT EXISTS (" + existsSQL + ")"
+		err = db.QueryRow(query, existsArgs...).Scan(&exists)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -608,8 +692,7 @@ func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 			return
 		}
 
-		fields := ""
-		placeholders := make([]interface{}, 0)
+		set := make(map[string]interface{}, len(values))
 
 		for key, val := range values {
 			if !CheckType(key, val, table) || idName == key {
@@ -629,13 +712,10 @@ func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 				return
 			}
 
-			fields += "" + key + " = ?"
-			placeholders = append(placeholders, val)
-
+			set[key] = val
 		}
-		fields = strings.Replace(fields, "?", "?,", len(values)-1)
-		query = fmt.Sprintf("UPDATE "+table.name+" SET %s WHERE %s = "+id, fields, idName)
-		res, err := db.Exec(query, placeholders...)
+		query, queryArgs := builder.Update(table.name).Set(set).Where(builder.Eq{idName: id}).Build(dialect)
+		res, err := db.Exec(query, queryArgs...)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -646,6 +726,7 @@ func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		cacher.Invalidate(table.name)
 		resp := map[string]interface{}{
 			"response": map[string]interface{}{
 				"updated": rowsAff,
@@ -664,7 +745,7 @@ func UpdateRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 	}
 }
 
-func DeleteRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWriter, r *http.Request) {
+func DeleteRecord(tables map[string]*Table, db *sql.DB, dialect Dialect, cacher Cacher) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		table, err := CheckTable(w, r, tables)
 		if err != nil {
@@ -687,8 +768,8 @@ func DeleteRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 			}
 		}
 
-		query := "DELETE FROM " + table.name + " WHERE " + idName + " = ? "
-		res, err := db.Exec(query, id)
+		query, queryArgs := builder.Delete(table.name).Where(builder.Eq{idName: id}).Build(dialect)
+		res, err := db.Exec(query, queryArgs...)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -699,6 +780,7 @@ func DeleteRecord(tables map[string]*Table, db *sql.DB) func(w http.ResponseWrit
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		cacher.Invalidate(table.name)
 
 		resp := map[string]interface{}{
 			"response": map[string]interface{}{
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect hides the database-specific bits of table introspection and
+// SQL construction so the CRUD handlers can stay database-agnostic.
+// Column introspection itself stays on the generic db.Query +
+// rows.ColumnTypes() path in NewDBExplorerWithOptions, since that
+// already works across drivers and carries type information a
+// per-dialect describe step would have to duplicate.
+type Dialect interface {
+	// Quote wraps an identifier (table or column name) the way the
+	// target database expects it quoted.
+	Quote(ident string) string
+	// Placeholder returns the bind-parameter marker for the i-th
+	// argument (1-indexed) of a query.
+	Placeholder(i int) string
+	// ListTables returns every table name visible to db.
+	ListTables(db *sql.DB) ([]string, error)
+	// SupportsReturning reports whether this dialect must recover an
+	// inserted row's id via a RETURNING clause (read with QueryRow)
+	// rather than sql.Result.LastInsertId.
+	SupportsReturning() bool
+	// LastInsertID extracts the id of a just-inserted row from res.
+	// Only called when SupportsReturning is false.
+	LastInsertID(res sql.Result, pk string) (int64, error)
+	// TimestampColumnType returns the column type used to store a
+	// timestamp (e.g. _migrations.applied_at).
+	TimestampColumnType() string
+	// Now returns the SQL expression for the current timestamp.
+	Now() string
+}
+
+// mysqlDialect is the dialect this explorer has always targeted.
+type mysqlDialect struct{}
+
+func (mysqlDialect) Quote(ident string) string {
+	return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+}
+
+func (mysqlDialect) Placeholder(i int) string {
+	return "?"
+}
+
+func (mysqlDialect) ListTables(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("SHOW TABLES")
+	if err != nil {
+		return nil, err
+	}
+	defer CloseCheck(rows)
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (mysqlDialect) SupportsReturning() bool {
+	return false
+}
+
+func (mysqlDialect) LastInsertID(res sql.Result, pk string) (int64, error) {
+	return res.LastInsertId()
+}
+
+func (mysqlDialect) TimestampColumnType() string {
+	return "DATETIME"
+}
+
+func (mysqlDialect) Now() string {
+	return "NOW()"
+}
+
+// postgresDialect targets PostgreSQL: information_schema introspection,
+// $N placeholders, and RETURNING to recover the inserted id.
+type postgresDialect struct{}
+
+func (postgresDialect) Quote(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+func (postgresDialect) Placeholder(i int) string {
+	return "$" + strconv.Itoa(i)
+}
+
+func (postgresDialect) ListTables(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT table_name FROM information_schema.tables WHERE table_schema = 'public'`)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseCheck(rows)
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (postgresDialect) SupportsReturning() bool {
+	return true
+}
+
+func (postgresDialect) LastInsertID(res sql.Result, pk string) (int64, error) {
+	// lib/pq doesn't implement LastInsertId; callers must build the
+	// INSERT with a RETURNING clause and read it via QueryRow instead
+	// (see PutRecord, which branches on SupportsReturning).
+	return 0, fmt.Errorf("postgresDialect: insert id must be read via RETURNING, not LastInsertId")
+}
+
+func (postgresDialect) TimestampColumnType() string {
+	return "TIMESTAMP"
+}
+
+func (postgresDialect) Now() string {
+	return "NOW()"
+}
+
+// sqliteDialect targets SQLite.
+type sqliteDialect struct{}
+
+func (sqliteDialect) Quote(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+func (sqliteDialect) Placeholder(i int) string {
+	return "?"
+}
+
+func (sqliteDialect) ListTables(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseCheck(rows)
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (sqliteDialect) SupportsReturning() bool {
+	return false
+}
+
+func (sqliteDialect) LastInsertID(res sql.Result, pk string) (int64, error) {
+	return res.LastInsertId()
+}
+
+func (sqliteDialect) TimestampColumnType() string {
+	return "TEXT"
+}
+
+func (sqliteDialect) Now() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+// dialectForDriver picks the Dialect matching db's driver, falling back
+// to MySQL to preserve this explorer's historical default behavior.
+func dialectForDriver(db *sql.DB) Dialect {
+	driverType := fmt.Sprintf("%T", db.Driver())
+	switch {
+	case strings.Contains(strings.ToLower(driverType), "postgres") || strings.Contains(strings.ToLower(driverType), "pq."):
+		return postgresDialect{}
+	case strings.Contains(strings.ToLower(driverType), "sqlite"):
+		return sqliteDialect{}
+	default:
+		return mysqlDialect{}
+	}
+}
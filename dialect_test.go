@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+)
+
+// fakeResult is a minimal sql.Result for exercising LastInsertID
+// without a real database connection.
+type fakeResult struct {
+	id  int64
+	err error
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.id, r.err }
+func (r fakeResult) RowsAffected() (int64, error) { return 0, nil }
+
+func TestDialectQuote(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect Dialect
+		ident   string
+		want    string
+	}{
+		{"mysql", mysqlDialect{}, "users", "`users`"},
+		{"mysql escapes backtick", mysqlDialect{}, "a`b", "`a``b`"},
+		{"postgres", postgresDialect{}, "users", `"users"`},
+		{"postgres escapes quote", postgresDialect{}, `a"b`, `"a""b"`},
+		{"sqlite", sqliteDialect{}, "users", `"users"`},
+		{"sqlite escapes quote", sqliteDialect{}, `a"b`, `"a""b"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.dialect.Quote(tc.ident); got != tc.want {
+				t.Errorf("Quote(%q) = %q, want %q", tc.ident, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDialectPlaceholder(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect Dialect
+		i       int
+		want    string
+	}{
+		{"mysql", mysqlDialect{}, 1, "?"},
+		{"mysql", mysqlDialect{}, 3, "?"},
+		{"postgres", postgresDialect{}, 1, "$1"},
+		{"postgres", postgresDialect{}, 3, "$3"},
+		{"sqlite", sqliteDialect{}, 1, "?"},
+		{"sqlite", sqliteDialect{}, 3, "?"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.dialect.Placeholder(tc.i); got != tc.want {
+				t.Errorf("Placeholder(%d) = %q, want %q", tc.i, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDialectSupportsReturning(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect Dialect
+		want    bool
+	}{
+		{"mysql", mysqlDialect{}, false},
+		{"postgres", postgresDialect{}, true},
+		{"sqlite", sqliteDialect{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.dialect.SupportsReturning(); got != tc.want {
+				t.Errorf("SupportsReturning() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDialectLastInsertID(t *testing.T) {
+	if id, err := (mysqlDialect{}).LastInsertID(fakeResult{id: 42}, "id"); err != nil || id != 42 {
+		t.Errorf("mysql LastInsertID = (%d, %v), want (42, nil)", id, err)
+	}
+	if id, err := (sqliteDialect{}).LastInsertID(fakeResult{id: 7}, "id"); err != nil || id != 7 {
+		t.Errorf("sqlite LastInsertID = (%d, %v), want (7, nil)", id, err)
+	}
+	// postgresDialect never reaches LastInsertId in practice (PutRecord
+	// branches on SupportsReturning first); it still must fail loudly
+	// rather than silently returning a bogus id if it's ever called.
+	if _, err := (postgresDialect{}).LastInsertID(fakeResult{id: 1}, "id"); err == nil {
+		t.Error("postgres LastInsertID = nil error, want an error since lib/pq has no LastInsertId")
+	}
+}
+
+func TestDialectTimestampColumnTypeAndNow(t *testing.T) {
+	cases := []struct {
+		name          string
+		dialect       Dialect
+		timestampType string
+		now           string
+	}{
+		{"mysql", mysqlDialect{}, "DATETIME", "NOW()"},
+		{"postgres", postgresDialect{}, "TIMESTAMP", "NOW()"},
+		{"sqlite", sqliteDialect{}, "TEXT", "CURRENT_TIMESTAMP"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.dialect.TimestampColumnType(); got != tc.timestampType {
+				t.Errorf("TimestampColumnType() = %q, want %q", got, tc.timestampType)
+			}
+			if got := tc.dialect.Now(); got != tc.now {
+				t.Errorf("Now() = %q, want %q", got, tc.now)
+			}
+		})
+	}
+}
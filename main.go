@@ -9,18 +9,24 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
+
+	"github.com/ItserX/MySQL_Manager/migrations"
 )
 
 var (
-	DSN = ""
+	DSN        = ""
+	AdminToken = ""
 )
 
 func init() {
-	err := godotenv.Load()
-	if err != nil {
+	// A missing .env is fine when config comes from the environment
+	// directly (e.g. containers, `go test`); only a malformed .env that
+	// does exist is fatal.
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
 		log.Fatalf(err.Error())
 	}
 	DSN = os.Getenv("DSN")
+	AdminToken = os.Getenv("ADMIN_TOKEN")
 }
 
 func main() {
@@ -33,7 +39,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	handler, err := NewDBExplorer(db)
+	if _, err := migrations.Migrate(db, mysqlDialect{}, "up"); err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	handler, err := NewDBExplorerWithOptions(db, Options{AdminToken: AdminToken})
 	if err != nil {
 		log.Fatalf(err.Error())
 	}
@@ -0,0 +1,161 @@
+// Package migrations implements ordered, versioned schema migrations
+// for the explorer's backing database. Migrations are registered at
+// init time via Register and applied in ascending id order by Migrate,
+// each inside its own transaction, with progress recorded in a
+// _migrations table whose bookkeeping SQL is rendered through a
+// Dialect so it works against MySQL, PostgreSQL and SQLite alike.
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// Migration is one registered schema change, identified by id so
+// ordering does not depend on registration (init) order.
+type Migration struct {
+	ID   int64
+	Up   func(*sql.Tx) error
+	Down func(*sql.Tx) error
+}
+
+var registered []Migration
+
+// Register adds a migration to the set Migrate will consider. id must
+// be unique and migrations are applied in ascending id order,
+// regardless of the order Register is called in.
+func Register(id int64, up, down func(*sql.Tx) error) {
+	registered = append(registered, Migration{ID: id, Up: up, Down: down})
+}
+
+// Dialect is the subset of the explorer's Dialect that the
+// _migrations bookkeeping table needs: the bind-parameter style and
+// the database-specific timestamp column/expression.
+type Dialect interface {
+	Placeholder(i int) string
+	TimestampColumnType() string
+	Now() string
+}
+
+func createMigrationsTable(d Dialect) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS _migrations (
+	id BIGINT PRIMARY KEY,
+	applied_at %s
+)`, d.TimestampColumnType())
+}
+
+// Migrate brings the database up to date (direction "up") or rolls
+// back the most recently applied migration (direction "down"). For
+// "up" it returns the ids of the migrations it applied, in the order
+// applied; for "down" it returns the id it rolled back, if any.
+func Migrate(db *sql.DB, d Dialect, direction string) ([]int64, error) {
+	if _, err := db.Exec(createMigrationsTable(d)); err != nil {
+		return nil, err
+	}
+
+	ordered := make([]Migration, len(registered))
+	copy(ordered, registered)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	switch direction {
+	case "up", "":
+		return migrateUp(db, d, ordered, applied)
+	case "down":
+		return migrateDown(db, d, ordered, applied)
+	default:
+		return nil, fmt.Errorf("migrations: unknown direction %q", direction)
+	}
+}
+
+func appliedIDs(db *sql.DB) (map[int64]bool, error) {
+	rows, err := db.Query("SELECT id FROM _migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		applied[id] = true
+	}
+	return applied, rows.Err()
+}
+
+func migrateUp(db *sql.DB, d Dialect, ordered []Migration, applied map[int64]bool) ([]int64, error) {
+	appliedNow := make([]int64, 0)
+
+	for _, m := range ordered {
+		if applied[m.ID] {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return appliedNow, err
+		}
+
+		if err := m.Up(tx); err != nil {
+			tx.Rollback()
+			return appliedNow, fmt.Errorf("migrations: up %d: %w", m.ID, err)
+		}
+
+		recordSQL := fmt.Sprintf("INSERT INTO _migrations (id, applied_at) VALUES (%s, %s)", d.Placeholder(1), d.Now())
+		if _, err := tx.Exec(recordSQL, m.ID); err != nil {
+			tx.Rollback()
+			return appliedNow, fmt.Errorf("migrations: record %d: %w", m.ID, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return appliedNow, err
+		}
+
+		appliedNow = append(appliedNow, m.ID)
+	}
+
+	return appliedNow, nil
+}
+
+func migrateDown(db *sql.DB, d Dialect, ordered []Migration, applied map[int64]bool) ([]int64, error) {
+	var last *Migration
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if applied[ordered[i].ID] {
+			last = &ordered[i]
+			break
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := last.Down(tx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("migrations: down %d: %w", last.ID, err)
+	}
+
+	unrecordSQL := fmt.Sprintf("DELETE FROM _migrations WHERE id = %s", d.Placeholder(1))
+	if _, err := tx.Exec(unrecordSQL, last.ID); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("migrations: unrecord %d: %w", last.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return []int64{last.ID}, nil
+}
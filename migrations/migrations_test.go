@@ -0,0 +1,267 @@
+package migrations
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testDialect is a stand-in for the explorer's Dialect, using MySQL-style
+// bind parameters and timestamp handling.
+type testDialect struct{}
+
+func (testDialect) Placeholder(i int) string    { return "?" }
+func (testDialect) TimestampColumnType() string { return "DATETIME" }
+func (testDialect) Now() string                 { return "NOW()" }
+
+// fakeStore is the in-memory _migrations table a fakeConn reads/writes.
+// Connections sharing a DSN share a store, mirroring how a real driver's
+// connection pool all talk to the same database.
+type fakeStore struct {
+	mu  sync.Mutex
+	ids map[int64]bool
+}
+
+var (
+	storesMu sync.Mutex
+	stores   = map[string]*fakeStore{}
+)
+
+func storeFor(dsn string) *fakeStore {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	s, ok := stores[dsn]
+	if !ok {
+		s = &fakeStore{ids: map[int64]bool{}}
+		stores[dsn] = s
+	}
+	return s
+}
+
+// fakeDriver backs a *sql.DB with an in-memory _migrations table good
+// enough to exercise Migrate's ordering/up/down logic without a real
+// database.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	return &fakeConn{store: storeFor(dsn)}, nil
+}
+
+func init() {
+	sql.Register("migrationsfake", fakeDriver{})
+}
+
+type fakeConn struct{ store *fakeStore }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{store: c.store, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	store *fakeStore
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	q := strings.TrimSpace(s.query)
+	switch {
+	case strings.HasPrefix(q, "CREATE TABLE"):
+		return fakeResult{}, nil
+	case strings.HasPrefix(q, "INSERT INTO _migrations"):
+		id, err := argID(args)
+		if err != nil {
+			return nil, err
+		}
+		s.store.mu.Lock()
+		s.store.ids[id] = true
+		s.store.mu.Unlock()
+		return fakeResult{}, nil
+	case strings.HasPrefix(q, "DELETE FROM _migrations"):
+		id, err := argID(args)
+		if err != nil {
+			return nil, err
+		}
+		s.store.mu.Lock()
+		delete(s.store.ids, id)
+		s.store.mu.Unlock()
+		return fakeResult{}, nil
+	default:
+		return nil, fmt.Errorf("fakeStmt: unsupported exec %q", q)
+	}
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	q := strings.TrimSpace(s.query)
+	if !strings.HasPrefix(q, "SELECT id FROM _migrations") {
+		return nil, fmt.Errorf("fakeStmt: unsupported query %q", q)
+	}
+
+	s.store.mu.Lock()
+	ids := make([]int64, 0, len(s.store.ids))
+	for id := range s.store.ids {
+		ids = append(ids, id)
+	}
+	s.store.mu.Unlock()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return &fakeRows{ids: ids}, nil
+}
+
+func argID(args []driver.Value) (int64, error) {
+	if len(args) == 0 {
+		return 0, fmt.Errorf("fakeStmt: missing id arg")
+	}
+	id, ok := args[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("fakeStmt: bad id arg %T", args[0])
+	}
+	return id, nil
+}
+
+type fakeRows struct {
+	ids []int64
+	i   int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.ids) {
+		return io.EOF
+	}
+	dest[0] = r.ids[r.i]
+	r.i++
+	return nil
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+// openFakeDB returns a fresh *sql.DB backed by an empty in-memory
+// _migrations table, isolated from other tests by name.
+func openFakeDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("migrationsfake", t.Name())
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func withRegistered(t *testing.T, migs ...Migration) {
+	t.Helper()
+	saved := registered
+	registered = append([]Migration(nil), migs...)
+	t.Cleanup(func() { registered = saved })
+}
+
+func TestMigrateAppliesInAscendingIDOrder(t *testing.T) {
+	var order []int64
+	record := func(id int64) func(*sql.Tx) error {
+		return func(*sql.Tx) error {
+			order = append(order, id)
+			return nil
+		}
+	}
+
+	withRegistered(t,
+		Migration{ID: 20, Up: record(20), Down: record(20)},
+		Migration{ID: 5, Up: record(5), Down: record(5)},
+		Migration{ID: 10, Up: record(10), Down: record(10)},
+	)
+
+	db := openFakeDB(t)
+	applied, err := Migrate(db, testDialect{}, "up")
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	want := []int64{5, 10, 20}
+	if fmt.Sprint(applied) != fmt.Sprint(want) || fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Fatalf("got applied=%v order=%v, want %v in ascending id order", applied, order, want)
+	}
+}
+
+func TestMigrateUpIsIdempotent(t *testing.T) {
+	calls := 0
+	up := func(*sql.Tx) error { calls++; return nil }
+
+	withRegistered(t, Migration{ID: 1, Up: up, Down: up})
+
+	db := openFakeDB(t)
+	if _, err := Migrate(db, testDialect{}, "up"); err != nil {
+		t.Fatalf("first Migrate: %v", err)
+	}
+	applied, err := Migrate(db, testDialect{}, "up")
+	if err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+
+	if len(applied) != 0 || calls != 1 {
+		t.Fatalf("got applied=%v calls=%d, want no-op second run", applied, calls)
+	}
+}
+
+func TestMigrateDownRollsBackOnlyTheLastApplied(t *testing.T) {
+	var downed []int64
+	up := func(*sql.Tx) error { return nil }
+	down := func(id int64) func(*sql.Tx) error {
+		return func(*sql.Tx) error { downed = append(downed, id); return nil }
+	}
+
+	withRegistered(t,
+		Migration{ID: 1, Up: up, Down: down(1)},
+		Migration{ID: 2, Up: up, Down: down(2)},
+	)
+
+	db := openFakeDB(t)
+	if _, err := Migrate(db, testDialect{}, "up"); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	applied, err := Migrate(db, testDialect{}, "down")
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+
+	if fmt.Sprint(applied) != "[2]" || fmt.Sprint(downed) != "[2]" {
+		t.Fatalf("got applied=%v downed=%v, want only migration 2 rolled back", applied, downed)
+	}
+
+	// Running down again should now roll back migration 1.
+	applied, err = Migrate(db, testDialect{}, "down")
+	if err != nil {
+		t.Fatalf("second down: %v", err)
+	}
+	if fmt.Sprint(applied) != "[1]" {
+		t.Fatalf("got applied=%v, want migration 1 rolled back next", applied)
+	}
+}
+
+func TestMigrateUnknownDirection(t *testing.T) {
+	withRegistered(t)
+	db := openFakeDB(t)
+
+	if _, err := Migrate(db, testDialect{}, "sideways"); err == nil {
+		t.Fatal("want an error for an unknown direction")
+	}
+}
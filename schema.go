@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ColumnSchema is the JSON Schema-ish description of a single column,
+// derived from sql.ColumnType at introspection time.
+type ColumnSchema struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	DatabaseType string `json:"databaseType"`
+	Nullable     bool   `json:"nullable"`
+	Length       int64  `json:"length,omitempty"`
+	Precision    int64  `json:"precision,omitempty"`
+	Scale        int64  `json:"scale,omitempty"`
+}
+
+// TableSchema is the JSON Schema description of a whole table.
+type TableSchema struct {
+	Table      string                  `json:"table"`
+	Type       string                  `json:"type"`
+	Properties map[string]ColumnSchema `json:"properties"`
+	Required   []string                `json:"required"`
+}
+
+// jsonTypeFor maps a Go scan type name to a JSON Schema primitive type.
+func jsonTypeFor(scanType string) string {
+	switch {
+	case strings.Contains(scanType, "int"):
+		return "integer"
+	case strings.Contains(scanType, "float"):
+		return "number"
+	case scanType == "bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// BuildTableSchema turns a Table's cached columnTypes into a TableSchema.
+func BuildTableSchema(table *Table) TableSchema {
+	props := make(map[string]ColumnSchema, len(table.columnTypes))
+	required := make([]string, 0)
+
+	for _, col := range table.columnTypes {
+		nullable, _ := col.Nullable()
+		length, hasLength := col.Length()
+		precision, scale, hasPrecision := col.DecimalSize()
+
+		cs := ColumnSchema{
+			Name:         col.Name(),
+			Type:         jsonTypeFor(col.ScanType().Name()),
+			DatabaseType: col.DatabaseTypeName(),
+			Nullable:     nullable,
+		}
+		if hasLength {
+			cs.Length = length
+		}
+		if hasPrecision {
+			cs.Precision = precision
+			cs.Scale = scale
+		}
+
+		props[col.Name()] = cs
+		if !nullable {
+			required = append(required, col.Name())
+		}
+	}
+
+	sort.Strings(required)
+
+	return TableSchema{
+		Table:      table.name,
+		Type:       "object",
+		Properties: props,
+		Required:   required,
+	}
+}
+
+// GetSchema returns the JSON Schema for every table, or for a single table
+// when the request targets /_schema/{table}.
+func GetSchema(tables map[string]*Table) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tableName := schemaTableName(r.URL.Path)
+
+		if tableName == "" {
+			schemas := make(map[string]TableSchema, len(tables))
+			for name, table := range tables {
+				schemas[name] = BuildTableSchema(table)
+			}
+			writeJSON(w, schemas)
+			return
+		}
+
+		table, ok := tables[tableName]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, map[string]string{"error": "unknown table"})
+			return
+		}
+		writeJSON(w, BuildTableSchema(table))
+	}
+}
+
+func schemaTableName(path string) string {
+	const prefix = "/_schema/"
+	if !strings.HasPrefix(path, prefix) || path == prefix {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// OpenAPIDocument holds just enough of the OpenAPI 3.0 structure to
+// describe the generic CRUD routes this explorer exposes.
+type OpenAPIDocument struct {
+	OpenAPI string                 `json:"openapi"`
+	Info    map[string]string      `json:"info"`
+	Paths   map[string]interface{} `json:"paths"`
+}
+
+// BuildOpenAPI assembles an OpenAPI document describing the CRUD routes
+// for every known table, using each table's JSON Schema as the
+// request/response body schema.
+func BuildOpenAPI(tables map[string]*Table) OpenAPIDocument {
+	paths := make(map[string]interface{}, len(tables)*2+1)
+	paths["/"] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"summary": "list tables",
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": "table names"},
+			},
+		},
+	}
+
+	for name, table := range tables {
+		schema := BuildTableSchema(table)
+		list := "/" + name
+		collection := "/" + name + "/"
+		item := "/" + name + "/{id}"
+
+		paths[list] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":   "list records",
+				"responses": map[string]interface{}{"200": map[string]interface{}{"description": "records"}},
+			},
+		}
+		paths[collection] = map[string]interface{}{
+			"put": map[string]interface{}{
+				"summary":     "create record",
+				"requestBody": map[string]interface{}{"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}},
+				"responses":   map[string]interface{}{"200": map[string]interface{}{"description": "created id"}},
+			},
+		}
+		paths[item] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":   "get record",
+				"responses": map[string]interface{}{"200": map[string]interface{}{"description": "record", "content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}}},
+			},
+			"post": map[string]interface{}{
+				"summary":     "update record",
+				"requestBody": map[string]interface{}{"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}},
+				"responses":   map[string]interface{}{"200": map[string]interface{}{"description": "rows updated"}},
+			},
+			"delete": map[string]interface{}{
+				"summary":   "delete record",
+				"responses": map[string]interface{}{"200": map[string]interface{}{"description": "rows deleted"}},
+			},
+		}
+	}
+
+	return OpenAPIDocument{
+		OpenAPI: "3.0.0",
+		Info:    map[string]string{"title": "DB Explorer", "version": "1.0.0"},
+		Paths:   paths,
+	}
+}
+
+// GetOpenAPI serves the generated OpenAPI document for the current schema.
+func GetOpenAPI(tables map[string]*Table) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, BuildOpenAPI(tables))
+	}
+}
+
+// writeJSON marshals resp and writes it to w, mirroring the error
+// handling used by the other handlers in this package.
+func writeJSON(w http.ResponseWriter, resp interface{}) {
+	js, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(js)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}